@@ -2,15 +2,14 @@ package docs
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"strings"
-
-	"gopkg.in/yaml.v3"
 )
 
-const defaultDocsOutPath = "./internal/dist/openapi.yaml"
+const defaultDocsOutName = "./internal/dist/openapi"
 
 // ConfigBuilder represents a config structure which will be used for the YAML Builder (BuildDocs fn).
 //
@@ -18,48 +17,113 @@ const defaultDocsOutPath = "./internal/dist/openapi.yaml"
 // without introducing breaking API changes.
 type ConfigBuilder struct {
 	CustomPath string
+
+	// Format selects the registered Codec to use (e.g. "yaml", "json").
+	// Defaults to "yaml" when empty.
+	Format string
+
+	// Writer, when set, makes BuildDocs stream the encoded spec to it
+	// instead of writing to CustomPath on disk.
+	Writer io.Writer
+
+	// NoFileWrite skips the on-disk write entirely, for callers that only
+	// want the encoded spec held in memory (see ServeLive).
+	NoFileWrite bool
+
+	// WatchDir is the source directory ServeLive watches for changes that
+	// should trigger a spec rebuild. Defaults to "." when empty.
+	WatchDir string
+
+	// Regenerate, when set, is called by ServeLive on every detected change
+	// instead of re-encoding the original OAS struct. Useful when routes or
+	// schemas are registered dynamically.
+	Regenerate func() (*OAS, error)
+
+	// KubernetesWrap, when set, routes the encoded spec through a
+	// Kubernetes manifest wrapper (see docs/k8s.K8sWrapConfig) before
+	// BuildDocs writes or streams it.
+	KubernetesWrap SpecWrapper
+}
+
+// SpecWrapper wraps an already-encoded spec in another document format
+// before BuildDocs writes or streams it. It exists so docs/k8s can plug
+// into ConfigBuilder.KubernetesWrap without this package importing it back.
+type SpecWrapper interface {
+	Wrap(spec []byte) ([]byte, error)
+}
+
+func (cb ConfigBuilder) getPath(codec Codec) string {
+	if cb.CustomPath != emptyStr {
+		return cb.CustomPath
+	}
+
+	return defaultDocsOutName + codec.Extension()
 }
 
-func (cb ConfigBuilder) getPath() string {
-	return cb.CustomPath
+func getPathFromFirstElement(cbs []ConfigBuilder, codec Codec) string {
+	if len(cbs) == 0 {
+		return defaultDocsOutName + codec.Extension()
+	}
+
+	return cbs[0].getPath(codec)
 }
 
-func getPathFromFirstElement(cbs []ConfigBuilder) string {
+func getFirstElement(cbs []ConfigBuilder) ConfigBuilder {
 	if len(cbs) == 0 {
-		return defaultDocsOutPath
+		return ConfigBuilder{}
 	}
 
-	return cbs[0].getPath()
+	return cbs[0]
 }
 
-// BuildDocs marshals the OAS struct to YAML and saves it to the chosen output file.
+// BuildDocs encodes the OAS struct using the Codec selected by
+// ConfigBuilder.Format and saves it to the chosen output file, or streams
+// it to ConfigBuilder.Writer when set.
 //
 // Returns an error if there is any.
 func (o *OAS) BuildDocs(conf ...ConfigBuilder) error {
 	o.initCallStackForRoutes()
 
-	yml, err := marshalToYAML(o)
+	cb := getFirstElement(conf)
+
+	codec, err := codecFor(cb.Format)
 	if err != nil {
+		return err
+	}
+
+	ho := o.transformToHybridOAS()
+
+	buf := &bytes.Buffer{}
+	if err = codec.Encode(buf, &ho); err != nil {
 		return fmt.Errorf("marshaling issue occurred: %w", err)
 	}
 
-	err = createYAMLOutFile(getPathFromFirstElement(conf), yml)
-	if err != nil {
-		return fmt.Errorf("an issue occurred while saving to YAML output: %w", err)
+	encoded := buf.Bytes()
+
+	if cb.KubernetesWrap != nil {
+		if encoded, err = cb.KubernetesWrap.Wrap(encoded); err != nil {
+			return fmt.Errorf("failed wrapping spec for kubernetes: %w", err)
+		}
 	}
 
-	return nil
-}
+	if cb.Writer != nil {
+		if _, err = cb.Writer.Write(encoded); err != nil {
+			return fmt.Errorf("failed streaming encoded output: %w", err)
+		}
+
+		return nil
+	}
 
-func marshalToYAML(oas *OAS) ([]byte, error) {
-	transformedOAS := oas.transformToHybridOAS()
+	if cb.NoFileWrite {
+		return nil
+	}
 
-	yml, err := yaml.Marshal(transformedOAS)
+	err = createYAMLOutFile(getPathFromFirstElement(conf, codec), encoded)
 	if err != nil {
-		return yml, fmt.Errorf("failed marshaling to yaml: %w", err)
+		return fmt.Errorf("an issue occurred while saving to YAML output: %w", err)
 	}
 
-	return yml, nil
+	return nil
 }
 
 func createYAMLOutFile(outPath string, marshaledYAML []byte) error {
@@ -102,13 +166,13 @@ type (
 )
 
 type hybridOAS struct {
-	OpenAPI      OASVersion    `yaml:"openapi"`
-	Info         Info          `yaml:"info"`
-	ExternalDocs ExternalDocs  `yaml:"externalDocs"`
-	Servers      Servers       `yaml:"servers"`
-	Tags         Tags          `yaml:"tags"`
-	Paths        pathsMap      `yaml:"paths"`
-	Components   componentsMap `yaml:"components"`
+	OpenAPI      OASVersion    `yaml:"openapi" json:"openapi"`
+	Info         Info          `yaml:"info" json:"info"`
+	ExternalDocs ExternalDocs  `yaml:"externalDocs" json:"externalDocs"`
+	Servers      Servers       `yaml:"servers" json:"servers"`
+	Tags         Tags          `yaml:"tags" json:"tags"`
+	Paths        pathsMap      `yaml:"paths" json:"paths"`
+	Components   componentsMap `yaml:"components" json:"components"`
 }
 
 func (o *OAS) transformToHybridOAS() hybridOAS {
@@ -0,0 +1,77 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatYAML = "yaml"
+	formatJSON = "json"
+)
+
+// Codec encodes a hybridOAS tree to an io.Writer in a specific wire format.
+//
+// Registering a new Codec in codecs lets BuildDocs emit additional formats,
+// selected through ConfigBuilder.Format, without changing its call sites.
+// ServeSwaggerUI always serves the spec through JSONCodec.
+type Codec interface {
+	Encode(w io.Writer, v *hybridOAS) error
+	Extension() string
+}
+
+var codecs = map[string]Codec{
+	formatYAML: YAMLCodec{},
+	formatJSON: JSONCodec{},
+}
+
+func codecFor(format string) (Codec, error) {
+	if format == emptyStr {
+		format = formatYAML
+	}
+
+	codec, ok := codecs[format]
+	if !ok {
+		return nil, fmt.Errorf("no codec registered for format %q", format)
+	}
+
+	return codec, nil
+}
+
+// YAMLCodec encodes the spec as YAML, matching the historical BuildDocs output.
+type YAMLCodec struct{}
+
+// Encode writes v to w as YAML.
+func (YAMLCodec) Encode(w io.Writer, v *hybridOAS) error {
+	if err := yaml.NewEncoder(w).Encode(v); err != nil {
+		return fmt.Errorf("failed encoding to yaml: %w", err)
+	}
+
+	return nil
+}
+
+// Extension returns the file extension used for YAML output.
+func (YAMLCodec) Extension() string {
+	return ".yaml"
+}
+
+// JSONCodec encodes the spec as JSON, for consumers such as Swagger UI's
+// /openapi.json or an HTTP handler that wants application/json.
+type JSONCodec struct{}
+
+// Encode writes v to w as JSON.
+func (JSONCodec) Encode(w io.Writer, v *hybridOAS) error {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		return fmt.Errorf("failed encoding to json: %w", err)
+	}
+
+	return nil
+}
+
+// Extension returns the file extension used for JSON output.
+func (JSONCodec) Extension() string {
+	return ".json"
+}
@@ -0,0 +1,81 @@
+package docs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCodecFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		wantCodec Codec
+		wantErr   bool
+	}{
+		{name: "empty format defaults to yaml", format: "", wantCodec: YAMLCodec{}},
+		{name: "yaml", format: formatYAML, wantCodec: YAMLCodec{}},
+		{name: "json", format: formatJSON, wantCodec: JSONCodec{}},
+		{name: "unknown format errors", format: "toml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			codec, err := codecFor(tt.format)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for format %q, got none", tt.format)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if codec != tt.wantCodec {
+				t.Fatalf("got codec %#v, want %#v", codec, tt.wantCodec)
+			}
+		})
+	}
+}
+
+func TestYAMLCodecEncode(t *testing.T) {
+	ho := hybridOAS{OpenAPI: OASVersion("3.0.0")}
+
+	var buf bytes.Buffer
+	if err := (YAMLCodec{}).Encode(&buf, &ho); err != nil {
+		t.Fatalf("YAMLCodec.Encode failed: %s", err)
+	}
+
+	if !strings.Contains(buf.String(), "openapi: 3.0.0") {
+		t.Errorf("expected yaml output to contain the openapi key, got:\n%s", buf.String())
+	}
+}
+
+func TestJSONCodecEncode(t *testing.T) {
+	ho := hybridOAS{OpenAPI: OASVersion("3.0.0")}
+
+	var buf bytes.Buffer
+	if err := (JSONCodec{}).Encode(&buf, &ho); err != nil {
+		t.Fatalf("JSONCodec.Encode failed: %s", err)
+	}
+
+	if !strings.Contains(buf.String(), `"openapi":"3.0.0"`) {
+		t.Errorf("expected json output to use the openapi key, got:\n%s", buf.String())
+	}
+}
+
+func TestCodecExtension(t *testing.T) {
+	if got := (YAMLCodec{}).Extension(); got != ".yaml" {
+		t.Errorf("YAMLCodec.Extension() = %q, want %q", got, ".yaml")
+	}
+
+	if got := (JSONCodec{}).Extension(); got != ".json" {
+		t.Errorf("JSONCodec.Extension() = %q, want %q", got, ".json")
+	}
+}
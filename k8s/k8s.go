@@ -0,0 +1,139 @@
+// Package k8s wraps a generated OpenAPI spec inside Kubernetes ConfigMap or
+// Secret manifests, so the BuildDocs output becomes directly
+// kubectl-applyable alongside the service it documents.
+package k8s
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/kaynetik/docs"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	apiVersion     = "v1"
+	configMapKind  = "ConfigMap"
+	secretKind     = "Secret"
+	openAPIDataKey = "openapi.yaml"
+)
+
+// K8sWrapConfig controls how RenderConfigMap/RenderSecret wrap the
+// generated OpenAPI spec. It also implements docs.SpecWrapper, so it can be
+// assigned directly to docs.ConfigBuilder.KubernetesWrap to have BuildDocs
+// route through this package.
+type K8sWrapConfig struct {
+	Name, Namespace string
+
+	// AsSecret renders a Secret instead of a ConfigMap when used as a
+	// docs.SpecWrapper.
+	AsSecret bool
+
+	// StringData renders a ConfigMap's payload under stringData instead of
+	// data. Ignored for Secret, which always base64-encodes under data.
+	StringData bool
+}
+
+// Wrap implements docs.SpecWrapper.
+func (c K8sWrapConfig) Wrap(spec []byte) ([]byte, error) {
+	if c.AsSecret {
+		return renderSecret(c.Name, c.Namespace, spec)
+	}
+
+	return renderConfigMap(c.Name, c.Namespace, spec, c.StringData)
+}
+
+type objectMeta struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+type configMap struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   objectMeta        `yaml:"metadata"`
+	Data       map[string]string `yaml:"data,omitempty"`
+	StringData map[string]string `yaml:"stringData,omitempty"`
+}
+
+type secret struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   objectMeta        `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+}
+
+// RenderConfigMap wraps oas's generated OpenAPI YAML inside a Kubernetes
+// ConfigMap manifest, keyed under "openapi.yaml". When stringData is true
+// the spec is written under stringData instead of data.
+//
+// Returns an error if oas cannot be marshaled to YAML.
+func RenderConfigMap(name, namespace string, oas *docs.OAS, stringData bool) ([]byte, error) {
+	spec, err := marshalSpec(oas)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderConfigMap(name, namespace, spec, stringData)
+}
+
+// RenderSecret wraps oas's generated OpenAPI YAML inside a Kubernetes
+// Secret manifest, base64-encoded under data["openapi.yaml"].
+//
+// Returns an error if oas cannot be marshaled to YAML.
+func RenderSecret(name, namespace string, oas *docs.OAS) ([]byte, error) {
+	spec, err := marshalSpec(oas)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderSecret(name, namespace, spec)
+}
+
+func renderConfigMap(name, namespace string, spec []byte, stringData bool) ([]byte, error) {
+	cm := configMap{
+		APIVersion: apiVersion,
+		Kind:       configMapKind,
+		Metadata:   objectMeta{Name: name, Namespace: namespace},
+	}
+
+	if stringData {
+		cm.StringData = map[string]string{openAPIDataKey: string(spec)}
+	} else {
+		cm.Data = map[string]string{openAPIDataKey: string(spec)}
+	}
+
+	yml, err := yaml.Marshal(cm)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling ConfigMap: %w", err)
+	}
+
+	return yml, nil
+}
+
+func renderSecret(name, namespace string, spec []byte) ([]byte, error) {
+	sec := secret{
+		APIVersion: apiVersion,
+		Kind:       secretKind,
+		Metadata:   objectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{openAPIDataKey: base64.StdEncoding.EncodeToString(spec)},
+	}
+
+	yml, err := yaml.Marshal(sec)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling Secret: %w", err)
+	}
+
+	return yml, nil
+}
+
+func marshalSpec(oas *docs.OAS) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := oas.BuildDocs(docs.ConfigBuilder{Format: "yaml", Writer: &buf}); err != nil {
+		return nil, fmt.Errorf("failed generating openapi spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestRenderConfigMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		stringData bool
+		wantKey    string
+	}{
+		{name: "data by default", stringData: false, wantKey: "data:"},
+		{name: "stringData when requested", stringData: true, wantKey: "stringData:"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			yml, err := renderConfigMap("my-api", "default", []byte("openapi: 3.0.0"), tt.stringData)
+			if err != nil {
+				t.Fatalf("renderConfigMap failed: %s", err)
+			}
+
+			got := string(yml)
+
+			for _, want := range []string{"kind: ConfigMap", "name: my-api", "namespace: default", tt.wantKey, "openapi: 3.0.0"} {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected manifest to contain %q, got:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderSecret(t *testing.T) {
+	spec := []byte("openapi: 3.0.0")
+
+	yml, err := renderSecret("my-api", "default", spec)
+	if err != nil {
+		t.Fatalf("renderSecret failed: %s", err)
+	}
+
+	got := string(yml)
+
+	for _, want := range []string{"kind: Secret", "name: my-api", "namespace: default"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected manifest to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	wantEncoded := base64.StdEncoding.EncodeToString(spec)
+	if !strings.Contains(got, wantEncoded) {
+		t.Errorf("expected manifest to contain base64-encoded spec %q, got:\n%s", wantEncoded, got)
+	}
+
+	if strings.Contains(got, string(spec)) {
+		t.Error("expected spec to be base64-encoded, found it in plaintext")
+	}
+}
@@ -0,0 +1,251 @@
+package docs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	openAPIYAMLRoute = "/openapi.yaml"
+	reloadRoute      = "/openapi/reload"
+)
+
+// liveSpec holds the most recently generated spec, in both YAML and JSON
+// form, guarded by a mutex since fsnotify and the HTTP handlers touch it
+// from different goroutines.
+type liveSpec struct {
+	mu   sync.RWMutex
+	yaml []byte
+	json []byte
+}
+
+func (ls *liveSpec) set(yml, jsn []byte) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.yaml = yml
+	ls.json = jsn
+}
+
+func (ls *liveSpec) get() (yml, jsn []byte) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	return ls.yaml, ls.json
+}
+
+// ServeLive combines BuildDocs and ServeSwaggerUI for local development: it
+// watches opts[0].WatchDir for source changes via fsnotify, regenerates the
+// OAS through opts[0].Regenerate when set, rebuilds the spec into memory, and
+// serves it at openAPIYAMLRoute and openAPIJSONRoute next to the UI assets.
+// Unless opts[0].NoFileWrite is set, every rebuild is also passed to
+// BuildDocs with opts[0], so it is written out as BuildDocs would. Connected
+// browsers are notified of rebuilds over a WebSocket at reloadRoute so they
+// can auto-refresh.
+//
+// Returns an error if there is any.
+func ServeLive(conf *ConfigSwaggerUI, oas *OAS, opts ...ConfigBuilder) error {
+	if conf == nil {
+		return errors.New("swagger config is required")
+	}
+
+	// ServeLive owns openAPIJSONRoute itself (serving the live-rebuilt
+	// spec), so it doesn't ask newSwaggerMux to also wire up conf.OAS.
+	muxConf := *conf
+	muxConf.OAS = nil
+
+	mux := newSwaggerMux(&muxConf)
+
+	cb := getFirstElement(opts)
+
+	spec := &liveSpec{}
+	if err := rebuildLiveSpec(spec, oas, cb); err != nil {
+		return fmt.Errorf("failed building initial spec for live serve: %w", err)
+	}
+
+	reloader := newReloadHub()
+
+	watcher, err := watchForChanges(cb.watchDirOrDefault(), func() {
+		regenerated := oas
+
+		if cb.Regenerate != nil {
+			var regenErr error
+
+			if regenerated, regenErr = cb.Regenerate(); regenErr != nil {
+				log.Printf("ServeLive: regenerate callback failed: %s\n", regenErr)
+
+				return
+			}
+		}
+
+		if rebuildErr := rebuildLiveSpec(spec, regenerated, cb); rebuildErr != nil {
+			log.Printf("ServeLive: failed rebuilding spec: %s\n", rebuildErr)
+
+			return
+		}
+
+		reloader.broadcast()
+	})
+	if err != nil {
+		return fmt.Errorf("failed starting source watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	mux.HandleFunc(openAPIYAMLRoute, serveLiveSpec(spec, pickYAML, "application/yaml"))
+	mux.HandleFunc(openAPIJSONRoute, serveLiveSpec(spec, pickJSON, "application/json"))
+	mux.HandleFunc(reloadRoute, reloader.handle)
+
+	return listenAndServe(conf, mux)
+}
+
+func rebuildLiveSpec(spec *liveSpec, oas *OAS, cb ConfigBuilder) error {
+	if oas == nil {
+		return errors.New("oas is required")
+	}
+
+	var ymlBuf, jsonBuf bytes.Buffer
+
+	if err := oas.BuildDocs(ConfigBuilder{Format: formatYAML, Writer: &ymlBuf}); err != nil {
+		return err
+	}
+
+	if err := oas.BuildDocs(ConfigBuilder{Format: formatJSON, Writer: &jsonBuf}); err != nil {
+		return err
+	}
+
+	if !cb.NoFileWrite {
+		if err := oas.BuildDocs(cb); err != nil {
+			return err
+		}
+	}
+
+	spec.set(ymlBuf.Bytes(), jsonBuf.Bytes())
+
+	return nil
+}
+
+func pickYAML(ls *liveSpec) []byte {
+	yml, _ := ls.get()
+
+	return yml
+}
+
+func pickJSON(ls *liveSpec) []byte {
+	_, jsn := ls.get()
+
+	return jsn
+}
+
+func serveLiveSpec(spec *liveSpec, pick func(*liveSpec) []byte, contentType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(pick(spec))
+	}
+}
+
+func (cb ConfigBuilder) watchDirOrDefault() string {
+	if cb.WatchDir == emptyStr {
+		return "."
+	}
+
+	return cb.WatchDir
+}
+
+func watchForChanges(dir string, onChange func()) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed creating fsnotify watcher: %w", err)
+	}
+
+	if err = watcher.Add(dir); err != nil {
+		return nil, fmt.Errorf("failed watching %s: %w", dir, err)
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
+					onChange()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				log.Printf("ServeLive: watcher error: %s\n", err)
+			}
+		}
+	}()
+
+	return watcher, nil
+}
+
+var liveUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// reloadHub tracks connected /openapi/reload clients and pushes a reload
+// event to all of them whenever broadcast is called.
+type reloadHub struct {
+	mu      sync.Mutex
+	clients map[*websocket.Conn]bool
+}
+
+func newReloadHub() *reloadHub {
+	return &reloadHub{clients: make(map[*websocket.Conn]bool)}
+}
+
+func (h *reloadHub) handle(w http.ResponseWriter, r *http.Request) {
+	conn, err := liveUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("ServeLive: websocket upgrade failed: %s\n", err)
+
+		return
+	}
+
+	h.mu.Lock()
+	h.clients[conn] = true
+	h.mu.Unlock()
+
+	go func() {
+		defer h.remove(conn)
+
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+}
+
+func (h *reloadHub) remove(conn *websocket.Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.clients, conn)
+	_ = conn.Close()
+}
+
+func (h *reloadHub) broadcast() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for conn := range h.clients {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte("reload")); err != nil {
+			log.Printf("ServeLive: failed notifying client: %s\n", err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package docs
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLiveSpecSetGet(t *testing.T) {
+	spec := &liveSpec{}
+
+	yml, jsn := spec.get()
+	if yml != nil || jsn != nil {
+		t.Fatalf("expected a zero-value liveSpec to hold nothing, got yaml=%q json=%q", yml, jsn)
+	}
+
+	spec.set([]byte("openapi: 3.0.0"), []byte(`{"openapi":"3.0.0"}`))
+
+	yml, jsn = spec.get()
+	if string(yml) != "openapi: 3.0.0" {
+		t.Errorf("got yaml %q, want %q", yml, "openapi: 3.0.0")
+	}
+
+	if string(jsn) != `{"openapi":"3.0.0"}` {
+		t.Errorf("got json %q, want %q", jsn, `{"openapi":"3.0.0"}`)
+	}
+}
+
+func TestPickYAMLAndJSON(t *testing.T) {
+	spec := &liveSpec{}
+	spec.set([]byte("yaml-body"), []byte("json-body"))
+
+	if got := string(pickYAML(spec)); got != "yaml-body" {
+		t.Errorf("pickYAML() = %q, want %q", got, "yaml-body")
+	}
+
+	if got := string(pickJSON(spec)); got != "json-body" {
+		t.Errorf("pickJSON() = %q, want %q", got, "json-body")
+	}
+}
+
+func TestRebuildLiveSpecRequiresOAS(t *testing.T) {
+	if err := rebuildLiveSpec(&liveSpec{}, nil, ConfigBuilder{}); err == nil {
+		t.Fatal("expected an error rebuilding a live spec from a nil OAS, got none")
+	}
+}
+
+func TestWatchDirOrDefault(t *testing.T) {
+	if got := (ConfigBuilder{}).watchDirOrDefault(); got != "." {
+		t.Errorf("watchDirOrDefault() = %q, want %q", got, ".")
+	}
+
+	if got := (ConfigBuilder{WatchDir: "./src"}).watchDirOrDefault(); got != "./src" {
+		t.Errorf("watchDirOrDefault() = %q, want %q", got, "./src")
+	}
+}
+
+func TestReloadHubBroadcastWithNoClients(t *testing.T) {
+	hub := newReloadHub()
+
+	// broadcast must be a no-op (not panic/block) when nobody is connected.
+	hub.broadcast()
+}
+
+func TestServeLiveSpecHandler(t *testing.T) {
+	spec := &liveSpec{}
+	spec.set([]byte("openapi: 3.0.0"), nil)
+
+	handler := serveLiveSpec(spec, pickYAML, "application/yaml")
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest("GET", "/openapi.yaml", nil))
+
+	if got := rec.Header().Get("Content-Type"); got != "application/yaml" {
+		t.Errorf("Content-Type = %q, want %q", got, "application/yaml")
+	}
+
+	if got := rec.Body.String(); got != "openapi: 3.0.0" {
+		t.Errorf("body = %q, want %q", got, "openapi: 3.0.0")
+	}
+}
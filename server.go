@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"path"
+	"path/filepath"
 	"strings"
 )
 
@@ -13,10 +15,40 @@ const (
 	defaultDirectory = "./internal/dist"
 	defaultIndexPath = "/index.html"
 	fwSlashSuffix    = "/"
+
+	headerAcceptEncoding  = "Accept-Encoding"
+	headerContentEncoding = "Content-Encoding"
+	headerContentType     = "Content-Type"
+	gzipEncoding          = "gzip"
+	gzipExt               = ".gz"
 )
 
+const openAPIJSONRoute = "/openapi.json"
+
+// assetsFS serves the Swagger UI assets from disk.
+//
+// NOTE: this package used to embed these assets via go:embed, but
+// ./internal/dist isn't committed to this repo (there is nothing for the
+// compiler to embed), which broke `go build` for every consumer. Revert to
+// reading the assets from disk until the asset files are actually checked
+// in, then switch back to embed.FS.
+var assetsFS http.FileSystem = http.Dir(defaultDirectory)
+
+// TLSConfig enables HTTPS for ServeSwaggerUI/ServeLive.
+type TLSConfig struct {
+	CertFile, KeyFile string
+}
+
 type ConfigSwaggerUI struct {
 	Route, Port string
+
+	// OAS, when set, makes ServeSwaggerUI additionally expose the spec as
+	// JSON at openAPIJSONRoute, next to the static UI assets.
+	OAS *OAS
+
+	// TLS, when set, makes ServeSwaggerUI/ServeLive listen over HTTPS
+	// using the given certificate and key instead of plain HTTP.
+	TLS *TLSConfig
 }
 
 func ServeSwaggerUI(conf *ConfigSwaggerUI) error {
@@ -24,34 +56,134 @@ func ServeSwaggerUI(conf *ConfigSwaggerUI) error {
 		return errors.New("swagger config is required")
 	}
 
-	route := conf.Route
+	mux := newSwaggerMux(conf)
+
+	return listenAndServe(conf, mux)
+}
 
+// newSwaggerMux builds a dedicated *http.ServeMux for the Swagger UI and
+// its supporting routes, rather than mutating http.DefaultServeMux, so
+// ServeSwaggerUI/ServeLive can run alongside a host application's own
+// routing without clobbering it.
+func newSwaggerMux(conf *ConfigSwaggerUI) *http.ServeMux {
+	route := conf.Route
 	if route == "" {
 		route = defaultRoute
 	}
 
-	fileServer := http.FileServer(FileSystem{http.Dir(defaultDirectory)})
-	http.Handle(route, http.StripPrefix(strings.TrimRight(route, fwSlashSuffix), fileServer))
+	mux := http.NewServeMux()
+
+	fileServer := http.FileServer(FileSystem{fs: assetsFS})
+	mux.Handle(route, http.StripPrefix(strings.TrimRight(route, fwSlashSuffix), gzipMiddleware(fileServer)))
+
+	if conf.OAS != nil {
+		mux.HandleFunc(openAPIJSONRoute, serveOpenAPIJSON(conf.OAS))
+	}
+
+	return mux
+}
 
+func listenAndServe(conf *ConfigSwaggerUI, handler http.Handler) error {
 	log.Printf("Serving SwaggerIU on HTTP port: %s\n", conf.Port)
 
-	if err := http.ListenAndServe(fmt.Sprintf(":%s", conf.Port), nil); err != nil {
+	addr := fmt.Sprintf(":%s", conf.Port)
+
+	var err error
+	if conf.TLS != nil {
+		err = http.ListenAndServeTLS(addr, conf.TLS.CertFile, conf.TLS.KeyFile, handler)
+	} else {
+		err = http.ListenAndServe(addr, handler)
+	}
+
+	if err != nil {
 		return fmt.Errorf("an error occurred while serving SwaggerUI: %w", err)
 	}
 
 	return nil
 }
 
+func serveOpenAPIJSON(oas *OAS) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ho := oas.transformToHybridOAS()
+
+		w.Header().Set(headerContentType, "application/json")
+
+		if err := (JSONCodec{}).Encode(w, &ho); err != nil {
+			http.Error(w, fmt.Sprintf("failed encoding openapi.json: %s", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+// gzipMiddleware sets an explicit Content-Type for the known spec/script
+// extensions and, when the client sent Accept-Encoding: gzip and a
+// precomputed ".gz" sibling of the requested asset exists, serves that
+// instead with Content-Encoding: gzip.
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setContentTypeFor(w, r.URL.Path)
+
+		if !strings.Contains(r.Header.Get(headerAcceptEncoding), gzipEncoding) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		gzipPath := r.URL.Path + gzipExt
+		if !assetExists(gzipPath) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		gzipReq := r.Clone(r.Context())
+		gzipReq.URL.Path = gzipPath
+		w.Header().Set(headerContentEncoding, gzipEncoding)
+
+		next.ServeHTTP(w, gzipReq)
+	})
+}
+
+func assetExists(assetPath string) bool {
+	f, err := assetsFS.Open(assetPath)
+	if err != nil {
+		return false
+	}
+
+	_ = f.Close()
+
+	return true
+}
+
+func setContentTypeFor(w http.ResponseWriter, reqPath string) {
+	switch filepath.Ext(strings.TrimSuffix(reqPath, gzipExt)) {
+	case ".yaml", ".yml":
+		w.Header().Set(headerContentType, "application/yaml")
+	case ".json":
+		w.Header().Set(headerContentType, "application/json")
+	case ".js":
+		w.Header().Set(headerContentType, "application/javascript")
+	}
+}
+
 type FileSystem struct {
-	// fs is wrapped to avoid unwanted dir traversal.
+	// fs is wrapped to reject paths that escape the asset root.
 	fs http.FileSystem
 }
 
-// Open opens file. Returns http.File, and error if there is any.
-func (fs FileSystem) Open(path string) (http.File, error) {
-	f, err := fs.fs.Open(path)
+// Open opens path within the swagger UI asset root. path is cleaned as a
+// rooted path, so ".." segments cannot climb above the root ("/../x" opens
+// "/x" under the root), and the cleaned path is checked with filepath.Rel
+// before it is opened.
+func (fs FileSystem) Open(reqPath string) (http.File, error) {
+	cleaned := path.Clean(fwSlashSuffix + reqPath)
+
+	if rel, err := filepath.Rel(fwSlashSuffix, cleaned); err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("failed to open file in path %s: path escapes asset root", reqPath)
+	}
+
+	f, err := fs.fs.Open(cleaned)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file in path %s :%w", path, err)
+		return nil, fmt.Errorf("failed to open file in path %s :%w", cleaned, err)
 	}
 
 	fileInfo, err := f.Stat()
@@ -60,7 +192,7 @@ func (fs FileSystem) Open(path string) (http.File, error) {
 	}
 
 	if fileInfo.IsDir() {
-		index := strings.TrimSuffix(path, fwSlashSuffix) + defaultIndexPath
+		index := strings.TrimSuffix(cleaned, fwSlashSuffix) + defaultIndexPath
 		if _, err = fs.fs.Open(index); err != nil {
 			return nil, fmt.Errorf("failed trimming path sufix :%w", err)
 		}
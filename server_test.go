@@ -0,0 +1,64 @@
+package docs
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestFileSystem(t *testing.T) FileSystem {
+	t.Helper()
+
+	root := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(root, "index.html"), []byte("<html></html>"), 0o600); err != nil {
+		t.Fatalf("failed writing fixture index.html: %s", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "openapi.yaml"), []byte("openapi: 3.0.0"), 0o600); err != nil {
+		t.Fatalf("failed writing fixture openapi.yaml: %s", err)
+	}
+
+	outsideDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outsideDir, "secret.txt"), []byte("top secret"), 0o600); err != nil {
+		t.Fatalf("failed writing fixture secret.txt: %s", err)
+	}
+
+	return FileSystem{fs: http.Dir(root)}
+}
+
+func TestFileSystemOpen(t *testing.T) {
+	tests := []struct {
+		name    string
+		reqPath string
+		wantErr bool
+	}{
+		{name: "existing file", reqPath: "/openapi.yaml", wantErr: false},
+		{name: "directory falls back to index.html", reqPath: "/", wantErr: false},
+		{name: "parent directory escape", reqPath: "/../secret.txt", wantErr: true},
+		{name: "nested parent directory escape", reqPath: "/sub/../../secret.txt", wantErr: true},
+		{name: "parent segment that stays inside the root", reqPath: "/openapi.yaml/../openapi.yaml", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newTestFileSystem(t)
+
+			f, err := fs.Open(tt.reqPath)
+			if f != nil {
+				defer f.Close()
+			}
+
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected an error opening %q, got none", tt.reqPath)
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error opening %q: %s", tt.reqPath, err)
+			}
+		})
+	}
+}
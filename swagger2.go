@@ -0,0 +1,525 @@
+package docs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCyclicRef is returned by resolveRefs when RefResolveMode is set to
+// RefResolveStrict and the walk re-enters a JSON pointer it has not
+// finished resolving yet further up the call stack.
+var ErrCyclicRef = errors.New("cyclic $ref detected")
+
+// RefResolveMode controls what resolveRefs does when it detects a cyclic
+// $ref while walking the YAML node tree.
+type RefResolveMode int
+
+const (
+	// RefResolveInline leaves a {$ref: ...} placeholder node in place of the
+	// cyclic reference instead of failing the whole resolve pass.
+	RefResolveInline RefResolveMode = iota
+	// RefResolveStrict aborts the resolve pass and returns ErrCyclicRef.
+	RefResolveStrict
+)
+
+const refKey = "$ref"
+
+// swagger2Doc is the subset of a Swagger 2.0 (OpenAPI 2) document that
+// ConvertSwagger2ToOAS3 and ConvertOAS3ToSwagger2 know how to translate.
+type swagger2Doc struct {
+	Swagger             string                            `yaml:"swagger"`
+	Info                Info                              `yaml:"info"`
+	Host                string                            `yaml:"host"`
+	BasePath            string                            `yaml:"basePath"`
+	Schemes             []string                          `yaml:"schemes"`
+	Paths               map[string]map[string]swagger2Op  `yaml:"paths"`
+	Definitions         map[string]swagger2Schema         `yaml:"definitions"`
+	SecurityDefinitions map[string]swagger2SecurityScheme `yaml:"securityDefinitions"`
+}
+
+type swagger2Op struct {
+	Tags        []string                `yaml:"tags"`
+	Summary     string                  `yaml:"summary"`
+	OperationID string                  `yaml:"operationId"`
+	Security    []map[string][]string   `yaml:"security"`
+	Responses   map[string]swagger2Resp `yaml:"responses"`
+}
+
+type swagger2Resp struct {
+	Description string `yaml:"description"`
+	Schema      struct {
+		Ref string `yaml:"$ref"`
+	} `yaml:"schema"`
+}
+
+type swagger2Schema struct {
+	Type       string                  `yaml:"type"`
+	Properties map[string]swagger2Prop `yaml:"properties"`
+}
+
+type swagger2Prop struct {
+	Type        string      `yaml:"type"`
+	Format      string      `yaml:"format"`
+	Description string      `yaml:"description"`
+	Enum        []string    `yaml:"enum"`
+	Default     interface{} `yaml:"default"`
+}
+
+type swagger2SecurityScheme struct {
+	Type string `yaml:"type"`
+	Name string `yaml:"name"`
+	In   string `yaml:"in"`
+}
+
+// ConvertSwagger2ToOAS3 parses a Swagger 2.0 document and migrates it into
+// an OAS struct, so users can load existing Swagger 2.0 specs without
+// hand-porting them to OpenAPI 3.
+//
+// mode controls how a cyclic $ref is handled and defaults to
+// RefResolveStrict when omitted: RefResolveStrict fails the conversion on a
+// cycle, while RefResolveInline leaves a {$ref: ...} placeholder in its
+// place and lets the conversion proceed.
+//
+// Returns an error if the input cannot be parsed as Swagger 2.0, or if it
+// contains a $ref cycle and mode is RefResolveStrict.
+func ConvertSwagger2ToOAS3(input []byte, mode ...RefResolveMode) (*OAS, error) {
+	resolveMode := RefResolveStrict
+	if len(mode) > 0 {
+		resolveMode = mode[0]
+	}
+
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(input, &root); err != nil {
+		return nil, fmt.Errorf("failed parsing swagger 2.0 document: %w", err)
+	}
+
+	resolved, err := resolveRefs(&root, &root, make(map[string]bool), resolveMode)
+	if err != nil {
+		return nil, fmt.Errorf("failed resolving $ref in swagger 2.0 document: %w", err)
+	}
+
+	var sw2 swagger2Doc
+
+	if err := resolved.Decode(&sw2); err != nil {
+		return nil, fmt.Errorf("failed decoding resolved swagger 2.0 document: %w", err)
+	}
+
+	oas := &OAS{
+		OASVersion: OASVersion("3.0.0"),
+		Info:       sw2.Info,
+		Servers:    swagger2ToServers(sw2),
+		Paths:      swagger2ToPaths(sw2.Paths),
+		Components: swagger2ToComponents(sw2),
+	}
+
+	return oas, nil
+}
+
+// ConvertOAS3ToSwagger2 downgrades an OAS struct to a Swagger 2.0 document,
+// for consumers that have not migrated to OpenAPI 3 tooling yet.
+//
+// Returns an error if the OAS struct cannot be marshaled.
+func ConvertOAS3ToSwagger2(oas *OAS) ([]byte, error) {
+	if oas == nil {
+		return nil, errors.New("oas is required")
+	}
+
+	host, basePath, schemes := serversToSwagger2(oas.Servers)
+	definitions, securityDefinitions := componentsToSwagger2(oas.Components)
+
+	sw2 := swagger2Doc{
+		Swagger:             "2.0",
+		Info:                oas.Info,
+		Host:                host,
+		BasePath:            basePath,
+		Schemes:             schemes,
+		Paths:               pathsToSwagger2(oas.Paths),
+		Definitions:         definitions,
+		SecurityDefinitions: securityDefinitions,
+	}
+
+	yml, err := yaml.Marshal(sw2)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling swagger 2.0 document: %w", err)
+	}
+
+	return yml, nil
+}
+
+func serversToSwagger2(servers Servers) (host, basePath string, schemes []string) {
+	if len(servers) == 0 {
+		return emptyStr, emptyStr, nil
+	}
+
+	parsed, err := url.Parse(servers[0].URL)
+	if err != nil {
+		return emptyStr, emptyStr, nil
+	}
+
+	scheme := parsed.Scheme
+	if scheme == emptyStr {
+		scheme = "https"
+	}
+
+	return parsed.Host, parsed.Path, []string{scheme}
+}
+
+func pathsToSwagger2(paths Paths) map[string]map[string]swagger2Op {
+	all := make(map[string]map[string]swagger2Op, len(paths))
+
+	for _, p := range paths {
+		if all[p.Route] == nil {
+			all[p.Route] = make(map[string]swagger2Op)
+		}
+
+		all[p.Route][strings.ToLower(p.HTTPMethod)] = swagger2Op{
+			Tags:        p.Tags,
+			Summary:     p.Summary,
+			OperationID: p.OperationID,
+			Security:    securityToSwagger2(p.Security),
+			Responses:   responsesToSwagger2(p.Responses),
+		}
+	}
+
+	return all
+}
+
+func securityToSwagger2(entities SecurityEntities) []map[string][]string {
+	security := make([]map[string][]string, 0, len(entities))
+
+	for _, entity := range entities {
+		security = append(security, map[string][]string{entity.AuthName: entity.PermTypes})
+	}
+
+	return security
+}
+
+func responsesToSwagger2(responses Responses) map[string]swagger2Resp {
+	all := make(map[string]swagger2Resp, len(responses))
+
+	for _, resp := range responses {
+		sw2Resp := swagger2Resp{Description: resp.Description}
+
+		if len(resp.Content) > 0 {
+			sw2Resp.Schema.Ref = "#/definitions/" + resp.Content[0].Schema
+		}
+
+		all[strconv.FormatUint(uint64(resp.Code), 10)] = sw2Resp
+	}
+
+	return all
+}
+
+func componentsToSwagger2(components Components) (map[string]swagger2Schema, map[string]swagger2SecurityScheme) {
+	if len(components) == 0 {
+		return nil, nil
+	}
+
+	component := components[0]
+
+	return schemasToSwagger2(component.Schemas), securitySchemesToSwagger2(component.SecuritySchemes)
+}
+
+func schemasToSwagger2(schemas Schemas) map[string]swagger2Schema {
+	all := make(map[string]swagger2Schema, len(schemas))
+
+	for _, s := range schemas {
+		all[s.Name] = swagger2Schema{Type: s.Type, Properties: propertiesToSwagger2(s.Properties)}
+	}
+
+	return all
+}
+
+func propertiesToSwagger2(properties SchemaProperties) map[string]swagger2Prop {
+	all := make(map[string]swagger2Prop, len(properties))
+
+	for _, prop := range properties {
+		all[prop.Name] = swagger2Prop{
+			Type:        prop.Type,
+			Format:      prop.Format,
+			Description: prop.Description,
+			Enum:        prop.Enum,
+			Default:     prop.Default,
+		}
+	}
+
+	return all
+}
+
+func securitySchemesToSwagger2(schemes SecuritySchemes) map[string]swagger2SecurityScheme {
+	all := make(map[string]swagger2SecurityScheme, len(schemes))
+
+	for _, scheme := range schemes {
+		all[scheme.Name] = swagger2SecurityScheme{Type: scheme.Type, Name: scheme.Name, In: scheme.In}
+	}
+
+	return all
+}
+
+func swagger2ToServers(sw2 swagger2Doc) Servers {
+	if sw2.Host == emptyStr {
+		return Servers{}
+	}
+
+	scheme := "https"
+	if len(sw2.Schemes) > 0 {
+		scheme = sw2.Schemes[0]
+	}
+
+	return Servers{{URL: fmt.Sprintf("%s://%s%s", scheme, sw2.Host, sw2.BasePath)}}
+}
+
+func swagger2ToPaths(paths map[string]map[string]swagger2Op) Paths {
+	allPaths := make(Paths, 0, len(paths))
+
+	for route, methods := range paths {
+		for method, op := range methods {
+			allPaths = append(allPaths, Path{
+				Route:       route,
+				HTTPMethod:  method,
+				Summary:     op.Summary,
+				OperationID: op.OperationID,
+				Security:    swagger2ToSecurity(op.Security),
+				Responses:   swagger2ToResponses(op.Responses),
+			})
+		}
+	}
+
+	return allPaths
+}
+
+func swagger2ToSecurity(security []map[string][]string) SecurityEntities {
+	entities := make(SecurityEntities, 0, len(security))
+
+	for _, sec := range security {
+		for authName, perms := range sec {
+			entities = append(entities, SecurityEntity{AuthName: authName, PermTypes: perms})
+		}
+	}
+
+	return entities
+}
+
+func swagger2ToResponses(responses map[string]swagger2Resp) Responses {
+	all := make(Responses, 0, len(responses))
+
+	for code, resp := range responses {
+		all = append(all, Response{
+			Code:        parseStatusCode(code),
+			Description: resp.Description,
+			Content: ContentTypes{
+				{Name: "application/json", Schema: strings.TrimPrefix(resp.Schema.Ref, "#/definitions/")},
+			},
+		})
+	}
+
+	return all
+}
+
+func swagger2ToComponents(sw2 swagger2Doc) Components {
+	return Components{{
+		Schemas:         swagger2ToSchemas(sw2.Definitions),
+		SecuritySchemes: swagger2ToSecuritySchemes(sw2.SecurityDefinitions),
+	}}
+}
+
+func swagger2ToSchemas(definitions map[string]swagger2Schema) Schemas {
+	schemas := make(Schemas, 0, len(definitions))
+
+	for name, def := range definitions {
+		schemas = append(schemas, Schema{
+			Name:       name,
+			Type:       def.Type,
+			Properties: swagger2ToProperties(def.Properties),
+		})
+	}
+
+	return schemas
+}
+
+func swagger2ToProperties(properties map[string]swagger2Prop) SchemaProperties {
+	props := make(SchemaProperties, 0, len(properties))
+
+	for name, prop := range properties {
+		props = append(props, SchemaProperty{
+			Name:        name,
+			Type:        prop.Type,
+			Format:      prop.Format,
+			Description: prop.Description,
+			Enum:        prop.Enum,
+			Default:     prop.Default,
+		})
+	}
+
+	return props
+}
+
+func swagger2ToSecuritySchemes(securityDefinitions map[string]swagger2SecurityScheme) SecuritySchemes {
+	schemes := make(SecuritySchemes, 0, len(securityDefinitions))
+
+	for name, sd := range securityDefinitions {
+		schemes = append(schemes, SecurityScheme{Name: name, Type: sd.Type, In: sd.In})
+	}
+
+	return schemes
+}
+
+func parseStatusCode(code string) uint {
+	var n uint
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return 0
+		}
+
+		n = n*10 + uint(r-'0')
+	}
+
+	return n
+}
+
+// resolveRefs walks a YAML node tree depth-first and resolves every $ref it
+// finds against root, inlining a copy of the referenced node in its place.
+//
+// visited tracks the JSON pointers currently being resolved on the active
+// DFS path. Re-entering one of them means the spec contains a $ref cycle:
+// depending on mode the offending node is either replaced with a
+// placeholder $ref node (RefResolveInline) or the walk aborts with
+// ErrCyclicRef (RefResolveStrict).
+func resolveRefs(node, root *yaml.Node, visited map[string]bool, mode RefResolveMode) (*yaml.Node, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return node, nil
+		}
+
+		resolved, err := resolveRefs(node.Content[0], root, visited, mode)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Content[0] = resolved
+
+		return node, nil
+	}
+
+	if node.Kind == yaml.MappingNode {
+		if pointer, ok := refPointer(node); ok {
+			if visited[pointer] {
+				if mode == RefResolveStrict {
+					return nil, fmt.Errorf("%w: %s", ErrCyclicRef, pointer)
+				}
+
+				return refPlaceholder(pointer), nil
+			}
+
+			target, err := lookupPointer(root, pointer)
+			if err != nil {
+				return nil, err
+			}
+
+			visited[pointer] = true
+
+			resolved, err := resolveRefs(copyNode(target), root, visited, mode)
+			if err != nil {
+				return nil, err
+			}
+
+			delete(visited, pointer)
+
+			return resolved, nil
+		}
+
+		for i := 1; i < len(node.Content); i += 2 {
+			resolved, err := resolveRefs(node.Content[i], root, visited, mode)
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content[i] = resolved
+		}
+
+		return node, nil
+	}
+
+	if node.Kind == yaml.SequenceNode {
+		for i, child := range node.Content {
+			resolved, err := resolveRefs(child, root, visited, mode)
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content[i] = resolved
+		}
+	}
+
+	return node, nil
+}
+
+func refPointer(node *yaml.Node) (string, bool) {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == refKey {
+			return node.Content[i+1].Value, true
+		}
+	}
+
+	return "", false
+}
+
+func refPlaceholder(pointer string) *yaml.Node {
+	var placeholder yaml.Node
+
+	_ = placeholder.Encode(map[string]string{refKey: pointer})
+
+	return &placeholder
+}
+
+// copyNode returns a deep copy of node, so resolving a $ref target never
+// rewrites the shared node that other references point at.
+func copyNode(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.Content = make([]*yaml.Node, len(node.Content))
+
+	for i, child := range node.Content {
+		copied.Content[i] = copyNode(child)
+	}
+
+	return &copied
+}
+
+func lookupPointer(root *yaml.Node, pointer string) (*yaml.Node, error) {
+	segments := strings.Split(strings.TrimPrefix(pointer, "#/"), "/")
+
+	current := root
+	if current.Kind == yaml.DocumentNode {
+		current = current.Content[0]
+	}
+
+	for _, segment := range segments {
+		found := false
+
+		for i := 0; i < len(current.Content)-1; i += 2 {
+			if current.Content[i].Value == segment {
+				current = current.Content[i+1]
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("failed resolving $ref pointer %s: segment %q not found", pointer, segment)
+		}
+	}
+
+	return current, nil
+}
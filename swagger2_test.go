@@ -0,0 +1,115 @@
+package docs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const cyclicSwagger2Doc = `
+swagger: "2.0"
+info:
+  title: Cyclic API
+  version: "1.0"
+definitions:
+  A:
+    type: object
+    properties:
+      b:
+        $ref: "#/definitions/B"
+  B:
+    type: object
+    properties:
+      a:
+        $ref: "#/definitions/A"
+`
+
+func TestConvertSwagger2ToOAS3_CyclicRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    []RefResolveMode
+		wantErr bool
+	}{
+		{name: "defaults to strict", mode: nil, wantErr: true},
+		{name: "strict rejects the cycle", mode: []RefResolveMode{RefResolveStrict}, wantErr: true},
+		{name: "inline tolerates the cycle", mode: []RefResolveMode{RefResolveInline}, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertSwagger2ToOAS3([]byte(cyclicSwagger2Doc), tt.mode...)
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrCyclicRef) {
+					t.Fatalf("expected ErrCyclicRef, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+const swagger2Doc = `
+swagger: "2.0"
+info:
+  title: Pet Store
+  version: "1.0"
+host: api.example.com
+basePath: /v1
+schemes:
+  - https
+definitions:
+  Pet:
+    type: object
+    properties:
+      name:
+        type: string
+securityDefinitions:
+  apiKey:
+    type: apiKey
+    name: X-API-Key
+    in: header
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      security:
+        - apiKey: []
+      responses:
+        "200":
+          description: OK
+          schema:
+            $ref: "#/definitions/Pet"
+`
+
+func TestConvertSwagger2ToOAS3ThenBack(t *testing.T) {
+	oas, err := ConvertSwagger2ToOAS3([]byte(swagger2Doc))
+	if err != nil {
+		t.Fatalf("ConvertSwagger2ToOAS3 failed: %s", err)
+	}
+
+	out, err := ConvertOAS3ToSwagger2(oas)
+	if err != nil {
+		t.Fatalf("ConvertOAS3ToSwagger2 failed: %s", err)
+	}
+
+	for _, want := range []string{"host: api.example.com", "basePath: /v1", "/pets:", "Pet:", "apiKey:"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestConvertOAS3ToSwagger2_NilOAS(t *testing.T) {
+	if _, err := ConvertOAS3ToSwagger2(nil); err == nil {
+		t.Fatal("expected an error converting a nil OAS, got none")
+	}
+}